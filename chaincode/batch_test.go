@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateRegistrationInput(t *testing.T) {
+	if err := validateRegistrationInput(DrugRegistrationInput{DrugID: "DRUG1"}); err != nil {
+		t.Errorf("unexpected error for a valid input: %v", err)
+	}
+	if err := validateRegistrationInput(DrugRegistrationInput{}); err == nil {
+		t.Errorf("expected an error when drugId is missing")
+	}
+}
+
+func TestRecordBatchOutcomePartialFailure(t *testing.T) {
+	result := &BatchRegistrationResult{Succeeded: []string{}, Failed: []BatchFailure{}}
+
+	recordBatchOutcome(result, "DRUG1", nil)
+	recordBatchOutcome(result, "DRUG2", errors.New("drug with ID DRUG2 already exists"))
+	recordBatchOutcome(result, "DRUG3", nil)
+
+	if want := []string{"DRUG1", "DRUG3"}; !equalStringSlices(result.Succeeded, want) {
+		t.Errorf("Succeeded = %v, want %v", result.Succeeded, want)
+	}
+	if len(result.Failed) != 1 {
+		t.Fatalf("expected exactly one failure, got %d: %v", len(result.Failed), result.Failed)
+	}
+	if result.Failed[0].DrugID != "DRUG2" {
+		t.Errorf("Failed[0].DrugID = %q, want %q", result.Failed[0].DrugID, "DRUG2")
+	}
+	if result.Failed[0].Reason != "drug with ID DRUG2 already exists" {
+		t.Errorf("Failed[0].Reason = %q, want the underlying error message", result.Failed[0].Reason)
+	}
+}
+
+func TestRecordBatchOutcomeAllFailSoNoneAreLostOrMisfiled(t *testing.T) {
+	result := &BatchRegistrationResult{Succeeded: []string{}, Failed: []BatchFailure{}}
+
+	recordBatchOutcome(result, "DRUG1", errors.New("drugId is required"))
+	recordBatchOutcome(result, "DRUG2", errors.New("drugId is required"))
+
+	if len(result.Succeeded) != 0 {
+		t.Errorf("expected no successes, got %v", result.Succeeded)
+	}
+	if len(result.Failed) != 2 {
+		t.Errorf("expected both records to be recorded as failures, got %v", result.Failed)
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,12 +1,28 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// drugPrivateCollection is the Fabric private data collection holding each
+// drug's confidential composition, inspection notes and batch-level pricing.
+const drugPrivateCollection = "drugPrivateCollection"
+
+// isDrugPrivateCollectionMember reports whether mspID is one of the orgs
+// listed in collections_config.json for drugPrivateCollection. Governed
+// actions can be executed by regulator MSPs (e.g. StateFDA, WHO observer)
+// that are not members of that collection, so callers must check this
+// before reading/writing private data on their behalf.
+func isDrugPrivateCollectionMember(mspID string) bool {
+	return mspID == "CiplaMSP" || mspID == "CDSCOMSP"
+}
+
 type Drug struct {
 	DrugID          string   `json:"drugId"`
 	Name            string   `json:"name"`
@@ -14,61 +30,721 @@ type Drug struct {
 	BatchNumber     string   `json:"batchNumber"`
 	MfgDate         string   `json:"mfgDate"`
 	ExpiryDate      string   `json:"expiryDate"`
-	Composition     string   `json:"composition"`
 	CurrentOwner    string   `json:"currentOwner"` // Cipla, Medlife, Apollo
-	Status          string   `json:"status"`       // InProduction, InTransit, Delivered, Recalled
+	Status          string   `json:"status"`       // InProduction, InTransit, Delivered, Recalled, LockedForTransfer, Transferred
 	History         []string `json:"history"`      // Format: "timestamp|event|from|to|details"
 	IsRecalled      bool     `json:"isRecalled"`
+	PrivateDataHash string   `json:"privateDataHash"` // sha256 of the DrugPrivate payload, for public audit
+}
+
+// DrugPrivate holds the confidential fields that live only in
+// drugPrivateCollection rather than the world state.
+type DrugPrivate struct {
+	DrugID          string   `json:"drugId"`
+	Composition     string   `json:"composition"`
 	InspectionNotes []string `json:"inspectionNotes"`
+	BatchPricing    string   `json:"batchPricing"`
+}
+
+type SmartContract struct {
+	contractapi.Contract
+}
+
+// CrossChainRecord tracks the lifecycle of a drug being transferred to/from a
+// partner chain (e.g. a customs-authority ledger tracking an export shipment).
+type CrossChainRecord struct {
+	DrugID       string `json:"drugId"`
+	SourceChain  string `json:"sourceChain"`
+	DestChain    string `json:"destChain"`
+	DestContract string `json:"destContract"`
+	DestAddress  string `json:"destAddress"`
+	Nonce        uint64 `json:"nonce"`
+	State        string `json:"state"` // Begin, Success, Failed, Rollback
+	Proof        string `json:"proof"`
+	PriorOwner   string `json:"priorOwner"`
+	PriorStatus  string `json:"priorStatus"`
+}
+
+const crossChainNonceKey = "crossChainNonce"
+
+func crossChainRecordKey(drugID string) string {
+	return "crossChainRecord~" + drugID
+}
+
+func processedAckKey(ctx contractapi.TransactionContextInterface, sourceChain string, nonce uint64) (string, error) {
+	return ctx.GetStub().CreateCompositeKey("crossChainAck", []string{sourceChain, fmt.Sprintf("%d", nonce)})
+}
+
+func nextCrossChainNonce(ctx contractapi.TransactionContextInterface) (uint64, error) {
+	bytes, err := ctx.GetStub().GetState(crossChainNonceKey)
+	if err != nil {
+		return 0, err
+	}
+	var nonce uint64
+	if bytes != nil {
+		nonce = binary.BigEndian.Uint64(bytes)
+	}
+	nonce++
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, nonce)
+	if err := ctx.GetStub().PutState(crossChainNonceKey, buf); err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+func getDrug(ctx contractapi.TransactionContextInterface, drugID string) (Drug, error) {
+	var drug Drug
+	drugBytes, err := ctx.GetStub().GetState(drugID)
+	if err != nil {
+		return drug, err
+	}
+	if drugBytes == nil {
+		return drug, fmt.Errorf("drug %s not found", drugID)
+	}
+	if err := json.Unmarshal(drugBytes, &drug); err != nil {
+		return drug, err
+	}
+	return drug, nil
+}
+
+func putDrug(ctx contractapi.TransactionContextInterface, drug Drug) error {
+	bytes, err := json.Marshal(drug)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(drug.DrugID, bytes)
+}
+
+func getCrossChainRecord(ctx contractapi.TransactionContextInterface, drugID string) (CrossChainRecord, error) {
+	var record CrossChainRecord
+	recordBytes, err := ctx.GetStub().GetState(crossChainRecordKey(drugID))
+	if err != nil {
+		return record, err
+	}
+	if recordBytes == nil {
+		return record, fmt.Errorf("no cross-chain record for drug %s", drugID)
+	}
+	if err := json.Unmarshal(recordBytes, &record); err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+func putCrossChainRecord(ctx contractapi.TransactionContextInterface, record CrossChainRecord) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(crossChainRecordKey(record.DrugID), bytes)
+}
+
+// appendPrivateInspectionNote appends a note to the drug's DrugPrivate
+// record (creating one if it doesn't exist yet) and returns the recomputed
+// hash of the updated payload so callers can refresh Drug.PrivateDataHash.
+func appendPrivateInspectionNote(ctx contractapi.TransactionContextInterface, drugID string, note string) (string, error) {
+	var private DrugPrivate
+
+	privateBytes, err := ctx.GetStub().GetPrivateData(drugPrivateCollection, drugID)
+	if err != nil {
+		return "", err
+	}
+	if privateBytes != nil {
+		if err := json.Unmarshal(privateBytes, &private); err != nil {
+			return "", err
+		}
+	} else {
+		private.DrugID = drugID
+	}
+
+	private.InspectionNotes = append(private.InspectionNotes, note)
+
+	updatedBytes, err := json.Marshal(private)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.GetStub().PutPrivateData(drugPrivateCollection, drugID, updatedBytes); err != nil {
+		return "", err
+	}
+
+	hashSum := sha256.Sum256(updatedBytes)
+	return hex.EncodeToString(hashSum[:]), nil
+}
+
+// ============== CROSS-CHAIN FUNCTIONS ==============
+
+// EmitCrossChainTransfer locks a drug for transfer to a partner chain and
+// emits a CrossChainRequest event for an off-chain relayer to pick up.
+func (s *SmartContract) EmitCrossChainTransfer(ctx contractapi.TransactionContextInterface,
+	drugID string, destChain string, destContract string, destAddress string) error {
+
+	drug, err := getDrug(ctx, drugID)
+	if err != nil {
+		return err
+	}
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || len(mspID) <= 3 || drug.CurrentOwner != mspID[:len(mspID)-3] {
+		return fmt.Errorf("only the current owner can initiate a cross-chain transfer")
+	}
+	if drug.Status == "LockedForTransfer" {
+		return fmt.Errorf("drug %s is already locked for a cross-chain transfer", drugID)
+	}
+
+	nonce, err := nextCrossChainNonce(ctx)
+	if err != nil {
+		return err
+	}
+
+	record := CrossChainRecord{
+		DrugID:       drugID,
+		SourceChain:  "drugLedger",
+		DestChain:    destChain,
+		DestContract: destContract,
+		DestAddress:  destAddress,
+		Nonce:        nonce,
+		State:        "Begin",
+		PriorOwner:   drug.CurrentOwner,
+		PriorStatus:  drug.Status,
+	}
+	if err := putCrossChainRecord(ctx, record); err != nil {
+		return err
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	drug.Status = "LockedForTransfer"
+	drug.History = append(drug.History, fmt.Sprintf("%s|CrossChainTransferEmitted|%s|%s|Nonce: %d",
+		timestamp, drug.CurrentOwner, destChain, nonce))
+	if err := putDrug(ctx, drug); err != nil {
+		return err
+	}
+
+	payload := struct {
+		Drug         Drug   `json:"drug"`
+		DestChain    string `json:"destChain"`
+		DestContract string `json:"destContract"`
+		DestAddress  string `json:"destAddress"`
+		Nonce        uint64 `json:"nonce"`
+	}{drug, destChain, destContract, destAddress, nonce}
+
+	eventBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().SetEvent("CrossChainRequest", eventBytes)
+}
+
+// ReceiveCrossChainAck is invoked by the relayer once the destination chain
+// has accepted or rejected the transfer. Only RelayerMSP may call this.
+func (s *SmartContract) ReceiveCrossChainAck(ctx contractapi.TransactionContextInterface,
+	drugID string, sourceChain string, sourceTxID string, status string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "RelayerMSP" {
+		return fmt.Errorf("only the registered relayer can submit cross-chain acks")
+	}
+
+	record, err := getCrossChainRecord(ctx, drugID)
+	if err != nil {
+		return err
+	}
+	if record.State != "Begin" {
+		return fmt.Errorf("cross-chain transfer for drug %s is not pending (state: %s)", drugID, record.State)
+	}
+
+	ackKey, err := processedAckKey(ctx, sourceChain, record.Nonce)
+	if err != nil {
+		return err
+	}
+	processed, err := ctx.GetStub().GetState(ackKey)
+	if err != nil {
+		return err
+	}
+	if processed != nil {
+		return fmt.Errorf("ack for %s nonce %d already processed", sourceChain, record.Nonce)
+	}
+
+	drug, err := getDrug(ctx, drugID)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case "Success":
+		timestamp, err := getTimestamp(ctx)
+		if err != nil {
+			return err
+		}
+		record.State = "Success"
+		record.Proof = sourceTxID
+		drug.Status = "Transferred"
+		drug.History = append(drug.History, fmt.Sprintf("%s|CrossChainTransferAcked|%s|%s|TxID: %s",
+			timestamp, record.PriorOwner, record.DestChain, sourceTxID))
+		if err := putDrug(ctx, drug); err != nil {
+			return err
+		}
+	case "Failed":
+		record.State = "Failed"
+		record.Proof = sourceTxID
+	default:
+		return fmt.Errorf("unknown cross-chain ack status %q", status)
+	}
+
+	if err := putCrossChainRecord(ctx, record); err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutState(ackKey, []byte(sourceTxID)); err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent("CrossChainAckReceived", []byte(drugID))
+}
+
+// RollbackCrossChainTransfer restores the drug's prior owner/status after a
+// failed cross-chain transfer. Only RelayerMSP may call this.
+func (s *SmartContract) RollbackCrossChainTransfer(ctx contractapi.TransactionContextInterface,
+	drugID string, reason string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "RelayerMSP" {
+		return fmt.Errorf("only the registered relayer can roll back cross-chain transfers")
+	}
+
+	record, err := getCrossChainRecord(ctx, drugID)
+	if err != nil {
+		return err
+	}
+	if record.State != "Failed" {
+		return fmt.Errorf("cross-chain transfer for drug %s is not in a Failed state (state: %s)", drugID, record.State)
+	}
+
+	drug, err := getDrug(ctx, drugID)
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	drug.CurrentOwner = record.PriorOwner
+	drug.Status = record.PriorStatus
+	drug.History = append(drug.History, fmt.Sprintf("%s|CrossChainTransferRolledBack|%s|-|Reason: %s",
+		timestamp, record.DestChain, reason))
+	if err := putDrug(ctx, drug); err != nil {
+		return err
+	}
+
+	record.State = "Rollback"
+	if err := putCrossChainRecord(ctx, record); err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent("CrossChainRolledBack", []byte(drugID))
+}
+
+func getMSPID(ctx contractapi.TransactionContextInterface) (string, error) {
+	return ctx.GetClientIdentity().GetMSPID()
+}
+
+// getTimestamp returns the transaction's agreed-upon timestamp rather than
+// time.Now(), which is non-deterministic and causes endorsement mismatches
+// when different peers execute the chaincode at different wall-clock times.
+func getTimestamp(ctx contractapi.TransactionContextInterface) (string, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+	return txTimestamp.AsTime().UTC().Format("2006-01-02 15:04:05"), nil
+}
+
+// ============== MANUFACTURER FUNCTIONS ==============
+
+// DrugRegistrationInput is the per-drug payload accepted by RegisterDrug and
+// its bulk variants, RegisterDrugBatch and RegisterDrugStreamed/CommitBatch.
+type DrugRegistrationInput struct {
+	DrugID      string `json:"drugId"`
+	Name        string `json:"name"`
+	BatchNumber string `json:"batchNumber"`
+	MfgDate     string `json:"mfgDate"`
+	ExpiryDate  string `json:"expiryDate"`
+}
+
+// validateRegistrationInput checks a single DrugRegistrationInput in
+// isolation, before any ledger access.
+func validateRegistrationInput(input DrugRegistrationInput) error {
+	if input.DrugID == "" {
+		return fmt.Errorf("drugId is required")
+	}
+	return nil
+}
+
+// registerDrugRecord validates and writes a single drug, shared by
+// RegisterDrug, RegisterDrugBatch and CommitBatch.
+
+func registerDrugRecord(ctx contractapi.TransactionContextInterface, input DrugRegistrationInput, timestamp string) error {
+	if err := validateRegistrationInput(input); err != nil {
+		return err
+	}
+
+	existing, err := ctx.GetStub().GetState(input.DrugID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("drug with ID %s already exists", input.DrugID)
+	}
+
+	drug := Drug{
+		DrugID:       input.DrugID,
+		Name:         input.Name,
+		Manufacturer: "Cipla",
+		BatchNumber:  input.BatchNumber,
+		MfgDate:      input.MfgDate,
+		ExpiryDate:   input.ExpiryDate,
+		CurrentOwner: "Cipla",
+		Status:       "InProduction",
+		IsRecalled:   false,
+		History: []string{
+			fmt.Sprintf("%s|Created|Cipla|-|Batch: %s", timestamp, input.BatchNumber),
+		},
+	}
+
+	bytes, err := json.Marshal(drug)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(input.DrugID, bytes)
+}
+
+func (s *SmartContract) RegisterDrug(ctx contractapi.TransactionContextInterface,
+	drugID string, name string, batchNumber string, mfgDate string, expiryDate string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "CiplaMSP" {
+		return fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	return registerDrugRecord(ctx, DrugRegistrationInput{
+		DrugID:      drugID,
+		Name:        name,
+		BatchNumber: batchNumber,
+		MfgDate:     mfgDate,
+		ExpiryDate:  expiryDate,
+	}, timestamp)
+}
+
+// BatchFailure records why a single drug within a bulk registration failed.
+type BatchFailure struct {
+	DrugID string `json:"drugId"`
+	Reason string `json:"reason"`
+}
+
+// BatchRegistrationResult summarizes the outcome of a bulk registration.
+type BatchRegistrationResult struct {
+	Succeeded []string       `json:"succeeded"`
+	Failed    []BatchFailure `json:"failed"`
+}
+
+// recordBatchOutcome appends drugID to result's Succeeded or Failed list
+// depending on whether err is nil.
+func recordBatchOutcome(result *BatchRegistrationResult, drugID string, err error) {
+	if err != nil {
+		result.Failed = append(result.Failed, BatchFailure{DrugID: drugID, Reason: err.Error()})
+		return
+	}
+	result.Succeeded = append(result.Succeeded, drugID)
+}
+
+// RegisterDrugBatch registers a JSON array of drugs in a single transaction.
+// Each record is validated independently, so a bad record in the batch
+// doesn't fail the ones around it; the caller gets a summary of both.
+func (s *SmartContract) RegisterDrugBatch(ctx contractapi.TransactionContextInterface, batchJSON string) (*BatchRegistrationResult, error) {
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "CiplaMSP" {
+		return nil, fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+	}
+
+	var inputs []DrugRegistrationInput
+	if err := json.Unmarshal([]byte(batchJSON), &inputs); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchRegistrationResult{Succeeded: []string{}, Failed: []BatchFailure{}}
+	for _, input := range inputs {
+		recordBatchOutcome(result, input.DrugID, registerDrugRecord(ctx, input, timestamp))
+	}
+
+	return result, nil
+}
+
+// batchStageMeta tracks how many chunks a streamed batch registration expects,
+// so CommitBatch knows when it has seen all of them.
+type batchStageMeta struct {
+	TotalChunks int `json:"totalChunks"`
+}
+
+func batchStageChunkKey(ctx contractapi.TransactionContextInterface, batchID string, chunkIdx int) (string, error) {
+	return ctx.GetStub().CreateCompositeKey("batchStage", []string{batchID, fmt.Sprintf("%d", chunkIdx)})
+}
+
+func batchStageMetaKey(ctx contractapi.TransactionContextInterface, batchID string) (string, error) {
+	return ctx.GetStub().CreateCompositeKey("batchStage", []string{batchID, "meta"})
+}
+
+// RegisterDrugStreamed stages one chunk of a large batch registration so a
+// manufacturer can onboard a full manufacturing run without keeping
+// per-item state in a single oversized transaction. Call CommitBatch once
+// every chunk has been staged.
+func (s *SmartContract) RegisterDrugStreamed(ctx contractapi.TransactionContextInterface,
+	batchID string, chunkIdx int, totalChunks int, payloadJSON string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "CiplaMSP" {
+		return fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+	}
+	if chunkIdx < 0 || chunkIdx >= totalChunks {
+		return fmt.Errorf("chunkIdx %d out of range for totalChunks %d", chunkIdx, totalChunks)
+	}
+
+	metaKey, err := batchStageMetaKey(ctx, batchID)
+	if err != nil {
+		return err
+	}
+	metaBytes, err := ctx.GetStub().GetState(metaKey)
+	if err != nil {
+		return err
+	}
+	if metaBytes == nil {
+		newMeta, err := json.Marshal(batchStageMeta{TotalChunks: totalChunks})
+		if err != nil {
+			return err
+		}
+		if err := ctx.GetStub().PutState(metaKey, newMeta); err != nil {
+			return err
+		}
+	} else {
+		var meta batchStageMeta
+		if err := json.Unmarshal(metaBytes, &meta); err != nil {
+			return err
+		}
+		if meta.TotalChunks != totalChunks {
+			return fmt.Errorf("totalChunks mismatch for batch %s: staged as %d, got %d", batchID, meta.TotalChunks, totalChunks)
+		}
+	}
+
+	chunkKey, err := batchStageChunkKey(ctx, batchID, chunkIdx)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(chunkKey, []byte(payloadJSON))
+}
+
+// CommitBatch materializes every staged chunk of batchID into registered
+// drugs in one transaction, then deletes the staging keys.
+func (s *SmartContract) CommitBatch(ctx contractapi.TransactionContextInterface, batchID string) (*BatchRegistrationResult, error) {
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "CiplaMSP" {
+		return nil, fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+	}
+
+	metaKey, err := batchStageMetaKey(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	metaBytes, err := ctx.GetStub().GetState(metaKey)
+	if err != nil {
+		return nil, err
+	}
+	if metaBytes == nil {
+		return nil, fmt.Errorf("no staged chunks for batch %s", batchID)
+	}
+	var meta batchStageMeta
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return nil, err
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchRegistrationResult{Succeeded: []string{}, Failed: []BatchFailure{}}
+	chunkKeys := make([]string, meta.TotalChunks)
+	for i := 0; i < meta.TotalChunks; i++ {
+		chunkKey, err := batchStageChunkKey(ctx, batchID, i)
+		if err != nil {
+			return nil, err
+		}
+		chunkKeys[i] = chunkKey
+
+		chunkBytes, err := ctx.GetStub().GetState(chunkKey)
+		if err != nil {
+			return nil, err
+		}
+		if chunkBytes == nil {
+			return nil, fmt.Errorf("missing chunk %d of %d for batch %s", i, meta.TotalChunks, batchID)
+		}
+
+		var inputs []DrugRegistrationInput
+		if err := json.Unmarshal(chunkBytes, &inputs); err != nil {
+			return nil, err
+		}
+		for _, input := range inputs {
+			recordBatchOutcome(result, input.DrugID, registerDrugRecord(ctx, input, timestamp))
+		}
+	}
+
+	for _, chunkKey := range chunkKeys {
+		if err := ctx.GetStub().DelState(chunkKey); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.GetStub().DelState(metaKey); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RegisterDrugWithPrivate registers a drug the same way as RegisterDrug, but
+// additionally takes the confidential composition (required) and batch
+// pricing from the transaction's transient map and writes them to
+// drugPrivateCollection. Inspection notes are not read from the transient
+// map; the private record starts with none. The on-chain Drug only stores a hash of that payload
+// so regulators can audit integrity without reading the confidential data.
+func (s *SmartContract) RegisterDrugWithPrivate(ctx contractapi.TransactionContextInterface,
+	drugID string, name string, batchNumber string, mfgDate string, expiryDate string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != "CiplaMSP" {
+		return fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+	}
+
+	existing, err := ctx.GetStub().GetState(drugID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("drug with ID %s already exists", drugID)
+	}
+
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return err
+	}
+	compositionBytes, ok := transientMap["composition"]
+	if !ok {
+		return fmt.Errorf("composition must be supplied via transient data")
+	}
+
+	private := DrugPrivate{
+		DrugID:       drugID,
+		Composition:  string(compositionBytes),
+		BatchPricing: string(transientMap["batchPricing"]),
+	}
+
+	privateBytes, err := json.Marshal(private)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutPrivateData(drugPrivateCollection, drugID, privateBytes); err != nil {
+		return err
+	}
+
+	hashSum := sha256.Sum256(privateBytes)
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	drug := Drug{
+		DrugID:          drugID,
+		Name:            name,
+		Manufacturer:    "Cipla",
+		BatchNumber:     batchNumber,
+		MfgDate:         mfgDate,
+		ExpiryDate:      expiryDate,
+		CurrentOwner:    "Cipla",
+		Status:          "InProduction",
+		IsRecalled:      false,
+		PrivateDataHash: hex.EncodeToString(hashSum[:]),
+		History: []string{
+			fmt.Sprintf("%s|Created|Cipla|-|Batch: %s", timestamp, batchNumber),
+		},
+	}
+
+	bytes, err := json.Marshal(drug)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(drugID, bytes)
 }
 
-type SmartContract struct {
-	contractapi.Contract
-}
+// GetDrugPrivate returns the confidential composition/inspection data for a
+// drug. Only MSPs that are members of drugPrivateCollection may call this.
+func (s *SmartContract) GetDrugPrivate(ctx contractapi.TransactionContextInterface, drugID string) (*DrugPrivate, error) {
+	mspID, err := getMSPID(ctx)
+	if err != nil || !isDrugPrivateCollectionMember(mspID) {
+		return nil, fmt.Errorf("only members of drugPrivateCollection can read private drug data")
+	}
 
-func getMSPID(ctx contractapi.TransactionContextInterface) (string, error) {
-	return ctx.GetClientIdentity().GetMSPID()
-}
+	privateBytes, err := ctx.GetStub().GetPrivateData(drugPrivateCollection, drugID)
+	if err != nil {
+		return nil, err
+	}
+	if privateBytes == nil {
+		return nil, fmt.Errorf("no private data for drug %s", drugID)
+	}
 
-func getTimestamp() string {
-	return time.Now().Format("2006-01-02 15:04:05")
+	var private DrugPrivate
+	if err := json.Unmarshal(privateBytes, &private); err != nil {
+		return nil, err
+	}
+	return &private, nil
 }
 
-// ============== MANUFACTURER FUNCTIONS ==============
-func (s *SmartContract) RegisterDrug(ctx contractapi.TransactionContextInterface,
-	drugID string, name string, batchNumber string, mfgDate string, expiryDate string, composition string) error {
-
-	mspID, err := getMSPID(ctx)
-	if err != nil || mspID != "CiplaMSP" {
-		return fmt.Errorf("only Cipla (Manufacturer) can register drugs")
+// VerifyDrugPrivateHash reads the SHA-256 hash that Fabric keeps on-chain for
+// the private payload currently held for drugID and confirms it matches both
+// the Drug's PrivateDataHash (set at registration and refreshed whenever an
+// inspection note is appended) and the hash supplied by the caller. This
+// lets a regulator who holds an off-chain copy of the composition data audit
+// its integrity without needing membership in the private collection.
+func (s *SmartContract) VerifyDrugPrivateHash(ctx contractapi.TransactionContextInterface, drugID string, providedHash string) (bool, error) {
+	drug, err := getDrug(ctx, drugID)
+	if err != nil {
+		return false, err
 	}
 
-	existing, err := ctx.GetStub().GetState(drugID)
+	// GetPrivateDataHash works on every peer and for non-member callers,
+	// unlike GetPrivateData on a memberOnlyRead collection.
+	hashBytes, err := ctx.GetStub().GetPrivateDataHash(drugPrivateCollection, drugID)
 	if err != nil {
-		return err
+		return false, err
 	}
-	if existing != nil {
-		return fmt.Errorf("drug with ID %s already exists", drugID)
+	if hashBytes == nil {
+		return false, fmt.Errorf("no private data recorded for drug %s", drugID)
 	}
 
-	drug := Drug{
-		DrugID:       drugID,
-		Name:         name,
-		Manufacturer: "Cipla",
-		BatchNumber:  batchNumber,
-		MfgDate:      mfgDate,
-		ExpiryDate:   expiryDate,
-		Composition:  composition,
-		CurrentOwner: "Cipla",
-		Status:       "InProduction",
-		IsRecalled:   false,
-		History: []string{
-			fmt.Sprintf("%s|Created|Cipla|-|Batch: %s", getTimestamp(), batchNumber),
-		},
-	}
+	computedHash := hex.EncodeToString(hashBytes)
 
-	bytes, _ := json.Marshal(drug)
-	return ctx.GetStub().PutState(drugID, bytes)
+	return computedHash == drug.PrivateDataHash && computedHash == providedHash, nil
 }
 
 // ============== DISTRIBUTION FUNCTIONS ==============
@@ -83,15 +759,23 @@ func (s *SmartContract) ShipDrug(ctx contractapi.TransactionContextInterface, dr
 		return err
 	}
 
-	mspID, _ := getMSPID(ctx)
-	if drug.CurrentOwner != mspID[:len(mspID)-3] { // e.g., "Cipla" from "CiplaMSP"
+	mspID, err := getMSPID(ctx)
+	if err != nil || len(mspID) <= 3 || drug.CurrentOwner != mspID[:len(mspID)-3] { // e.g., "Cipla" from "CiplaMSP"
 		return fmt.Errorf("only the current owner can ship this drug")
 	}
+	if drug.Status == "LockedForTransfer" {
+		return fmt.Errorf("drug %s is locked pending a cross-chain transfer", drugID)
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
 
 	from := drug.CurrentOwner
 	drug.CurrentOwner = to
 	drug.Status = "InTransit"
-	drug.History = append(drug.History, fmt.Sprintf("%s|Shipped|%s|%s|", getTimestamp(), from, to))
+	drug.History = append(drug.History, fmt.Sprintf("%s|Shipped|%s|%s|", timestamp, from, to))
 
 	// Emit an event (optional)
 	ctx.GetStub().SetEvent("DrugShipped", []byte(drugID))
@@ -101,12 +785,19 @@ func (s *SmartContract) ShipDrug(ctx contractapi.TransactionContextInterface, dr
 }
 
 // ============== REGULATOR FUNCTIONS ==============
+// RecallDrug no longer recalls a drug directly: a recall is a critical
+// action and must gather N-of-M regulator endorsements first. Callers must
+// go through ProposeAction(actionType="Recall")/EndorseAction/ExecuteAction;
+// this entrypoint only exists so legacy callers get an informative error
+// instead of a missing-function one.
 func (s *SmartContract) RecallDrug(ctx contractapi.TransactionContextInterface, drugID string, reason string) error {
-	mspID, err := getMSPID(ctx)
-	if err != nil || mspID != "CDSCOMSP" {
-		return fmt.Errorf("only CDSCO (Regulator) can recall drugs")
-	}
+	return fmt.Errorf(`direct recalls are disabled; propose a "Recall" action and gather the required endorsements via ProposeAction/EndorseAction/ExecuteAction`)
+}
 
+// applyRecallDrug performs the actual recall state transition. It is called
+// only by ExecuteAction, which dispatches here once a "Recall" PendingAction
+// has gathered enough endorsements; RecallDrug itself does not call it.
+func applyRecallDrug(ctx contractapi.TransactionContextInterface, drugID string, reason string, actor string) error {
 	drugBytes, err := ctx.GetStub().GetState(drugID)
 	if err != nil || drugBytes == nil {
 		return fmt.Errorf("drug %s not found", drugID)
@@ -115,10 +806,24 @@ func (s *SmartContract) RecallDrug(ctx contractapi.TransactionContextInterface,
 	var drug Drug
 	_ = json.Unmarshal(drugBytes, &drug)
 
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
 	drug.IsRecalled = true
 	drug.Status = "Recalled"
-	drug.InspectionNotes = append(drug.InspectionNotes, fmt.Sprintf("%s: %s", getTimestamp(), reason))
-	drug.History = append(drug.History, fmt.Sprintf("%s|Recalled|CDSCO|-|Reason: %s", getTimestamp(), reason))
+	drug.History = append(drug.History, fmt.Sprintf("%s|Recalled|%s|-|Reason: %s", timestamp, actor, reason))
+
+	if mspID, err := getMSPID(ctx); err == nil && isDrugPrivateCollectionMember(mspID) {
+		newHash, err := appendPrivateInspectionNote(ctx, drugID, fmt.Sprintf("%s: %s", timestamp, reason))
+		if err != nil {
+			return err
+		}
+		if newHash != "" {
+			drug.PrivateDataHash = newHash
+		}
+	}
 
 	// Emit recall event
 	ctx.GetStub().SetEvent("DrugRecalled", []byte(drugID))
@@ -127,6 +832,328 @@ func (s *SmartContract) RecallDrug(ctx contractapi.TransactionContextInterface,
 	return ctx.GetStub().PutState(drugID, bytes)
 }
 
+// applyInspectionApproval records a regulator inspection's outcome against a
+// drug once the governing multi-sig action has gathered enough endorsements.
+func applyInspectionApproval(ctx contractapi.TransactionContextInterface, drugID string, notes string, actor string) error {
+	drug, err := getDrug(ctx, drugID)
+	if err != nil {
+		return err
+	}
+
+	timestamp, err := getTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	drug.History = append(drug.History, fmt.Sprintf("%s|InspectionApproved|%s|-|%s", timestamp, actor, notes))
+
+	// Only collection members can read/write drugPrivateCollection; a governed
+	// InspectionApproval may be executed by a regulator MSP (e.g. StateFDA,
+	// WHO observer) that isn't one, so skip the private write for them rather
+	// than fail the whole approval on an authorization error.
+	if mspID, err := getMSPID(ctx); err == nil && isDrugPrivateCollectionMember(mspID) {
+		newHash, err := appendPrivateInspectionNote(ctx, drugID, fmt.Sprintf("%s: %s", timestamp, notes))
+		if err != nil {
+			return err
+		}
+		if newHash != "" {
+			drug.PrivateDataHash = newHash
+		}
+	}
+
+	ctx.GetStub().SetEvent("InspectionApproved", []byte(drugID))
+
+	return putDrug(ctx, drug)
+}
+
+// ============== GOVERNANCE / MULTI-SIG FUNCTIONS ==============
+
+// bootstrapAdminMSP is the consortium-admin organization allowed to configure
+// multi-sig thresholds. It is distinct from the business/regulator MSPs that
+// actually propose and endorse actions.
+const bootstrapAdminMSP = "ConsortiumAdminMSP"
+
+const governanceKey = "governance"
+
+// GovernanceConfig holds the per-action-type endorsement thresholds and the
+// set of regulator MSPs eligible to propose/endorse governed actions.
+type GovernanceConfig struct {
+	Thresholds map[string]int `json:"thresholds"` // actionType -> required endorsements
+	Regulators []string       `json:"regulators"` // MSPs eligible to propose/endorse
+}
+
+// PendingAction is a proposed governed action awaiting multi-sig endorsement,
+// persisted under composite key pendingAction~<actionID>.
+type PendingAction struct {
+	ActionID   string   `json:"actionId"`
+	ActionType string   `json:"actionType"` // "Recall" | "InspectionApproval"
+	DrugID     string   `json:"drugId"`
+	Payload    string   `json:"payload"` // action-specific JSON, e.g. {"reason":"..."}
+	Proposer   string   `json:"proposer"`
+	Endorsers  []string `json:"endorsers"`
+	Threshold  int      `json:"threshold"`
+	Expiry     string   `json:"expiry"`
+	Executed   bool     `json:"executed"`
+}
+
+func pendingActionKey(ctx contractapi.TransactionContextInterface, actionID string) (string, error) {
+	return ctx.GetStub().CreateCompositeKey("pendingAction", []string{actionID})
+}
+
+func getGovernanceConfig(ctx contractapi.TransactionContextInterface) (GovernanceConfig, error) {
+	var config GovernanceConfig
+	configBytes, err := ctx.GetStub().GetState(governanceKey)
+	if err != nil {
+		return config, err
+	}
+	if configBytes == nil {
+		return config, fmt.Errorf("governance is not configured yet")
+	}
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+func isRegulator(config GovernanceConfig, mspID string) bool {
+	for _, r := range config.Regulators {
+		if r == mspID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGovernanceConfig (re)writes the multi-sig thresholds and eligible
+// regulator MSPs. Only the bootstrap admin MSP may call this.
+func (s *SmartContract) SetGovernanceConfig(ctx contractapi.TransactionContextInterface, configJSON string) error {
+	mspID, err := getMSPID(ctx)
+	if err != nil || mspID != bootstrapAdminMSP {
+		return fmt.Errorf("only the bootstrap admin can configure governance")
+	}
+
+	var config GovernanceConfig
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return err
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(governanceKey, configBytes)
+}
+
+// ProposeAction opens a new multi-sig PendingAction. The proposer's own
+// endorsement is recorded immediately.
+func (s *SmartContract) ProposeAction(ctx contractapi.TransactionContextInterface,
+	actionID string, actionType string, drugID string, payloadJSON string) error {
+
+	mspID, err := getMSPID(ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := getGovernanceConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if !isRegulator(config, mspID) {
+		return fmt.Errorf("%s is not an eligible regulator for governed actions", mspID)
+	}
+
+	threshold, ok := config.Thresholds[actionType]
+	if !ok {
+		return fmt.Errorf("no configured threshold for action type %q", actionType)
+	}
+
+	key, err := pendingActionKey(ctx, actionID)
+	if err != nil {
+		return err
+	}
+	existing, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("pending action %s already exists", actionID)
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	expiry := txTimestamp.AsTime().Add(72 * time.Hour).Format(time.RFC3339)
+
+	action := PendingAction{
+		ActionID:   actionID,
+		ActionType: actionType,
+		DrugID:     drugID,
+		Payload:    payloadJSON,
+		Proposer:   mspID,
+		Endorsers:  []string{mspID},
+		Threshold:  threshold,
+		Expiry:     expiry,
+		Executed:   false,
+	}
+
+	actionBytes, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(key, actionBytes)
+}
+
+// EndorseAction records the caller's endorsement of a pending action. Each
+// eligible regulator MSP may endorse a given action at most once.
+func (s *SmartContract) EndorseAction(ctx contractapi.TransactionContextInterface, actionID string) error {
+	mspID, err := getMSPID(ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := getGovernanceConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if !isRegulator(config, mspID) {
+		return fmt.Errorf("%s is not an eligible regulator for governed actions", mspID)
+	}
+
+	key, err := pendingActionKey(ctx, actionID)
+	if err != nil {
+		return err
+	}
+	actionBytes, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return err
+	}
+	if actionBytes == nil {
+		return fmt.Errorf("pending action %s not found", actionID)
+	}
+
+	var action PendingAction
+	if err := json.Unmarshal(actionBytes, &action); err != nil {
+		return err
+	}
+	if action.Executed {
+		return fmt.Errorf("action %s has already been executed", actionID)
+	}
+	if err := checkActionNotExpired(ctx, action); err != nil {
+		return err
+	}
+
+	for _, e := range action.Endorsers {
+		if e == mspID {
+			return fmt.Errorf("%s has already endorsed action %s", mspID, actionID)
+		}
+	}
+	action.Endorsers = append(action.Endorsers, mspID)
+
+	updatedBytes, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(key, updatedBytes)
+}
+
+// ExecuteAction dispatches a pending action's payload to the underlying
+// handler once it has gathered at least Threshold endorsements and has not
+// expired.
+func (s *SmartContract) ExecuteAction(ctx contractapi.TransactionContextInterface, actionID string) error {
+	key, err := pendingActionKey(ctx, actionID)
+	if err != nil {
+		return err
+	}
+	actionBytes, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return err
+	}
+	if actionBytes == nil {
+		return fmt.Errorf("pending action %s not found", actionID)
+	}
+
+	var action PendingAction
+	if err := json.Unmarshal(actionBytes, &action); err != nil {
+		return err
+	}
+	if action.Executed {
+		return fmt.Errorf("action %s has already been executed", actionID)
+	}
+	if err := checkActionNotExpired(ctx, action); err != nil {
+		return err
+	}
+	if !isThresholdMet(action) {
+		return fmt.Errorf("action %s has %d of %d required endorsements", actionID, len(action.Endorsers), action.Threshold)
+	}
+
+	switch action.ActionType {
+	case "Recall":
+		var payload struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal([]byte(action.Payload), &payload); err != nil {
+			return err
+		}
+		if err := applyRecallDrug(ctx, action.DrugID, payload.Reason, action.Proposer); err != nil {
+			return err
+		}
+	case "InspectionApproval":
+		var payload struct {
+			Notes string `json:"notes"`
+		}
+		if err := json.Unmarshal([]byte(action.Payload), &payload); err != nil {
+			return err
+		}
+		if err := applyInspectionApproval(ctx, action.DrugID, payload.Notes, action.Proposer); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported action type %q", action.ActionType)
+	}
+
+	action.Executed = true
+	updatedBytes, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutState(key, updatedBytes); err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent("ActionExecuted", []byte(actionID))
+}
+
+// actionExpiredAt reports whether action's Expiry has passed as of now.
+func actionExpiredAt(action PendingAction, now time.Time) (bool, error) {
+	expiry, err := time.Parse(time.RFC3339, action.Expiry)
+	if err != nil {
+		return false, err
+	}
+	return now.After(expiry), nil
+}
+
+// isThresholdMet reports whether action has gathered enough endorsements to
+// execute.
+func isThresholdMet(action PendingAction) bool {
+	return len(action.Endorsers) >= action.Threshold
+}
+
+func checkActionNotExpired(ctx contractapi.TransactionContextInterface, action PendingAction) error {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	expired, err := actionExpiredAt(action, txTimestamp.AsTime())
+	if err != nil {
+		return err
+	}
+	if expired {
+		return fmt.Errorf("action %s expired at %s", action.ActionID, action.Expiry)
+	}
+	return nil
+}
+
 // ============== COMMON FUNCTIONS ==============
 func (s *SmartContract) TrackDrug(ctx contractapi.TransactionContextInterface, drugID string) (string, error) {
 	data, err := ctx.GetStub().GetState(drugID)
@@ -136,6 +1163,178 @@ func (s *SmartContract) TrackDrug(ctx contractapi.TransactionContextInterface, d
 	return string(data), nil
 }
 
+// HistoryEntry is one ledger-level modification to a Drug, as returned by
+// GetDrugHistory.
+type HistoryEntry struct {
+	TxID      string `json:"txId"`
+	Timestamp string `json:"timestamp"`
+	IsDelete  bool   `json:"isDelete"`
+	Drug      Drug   `json:"drug"`
+}
+
+// parseHistoryBound parses fromISO/toISO, which may be a full RFC3339
+// timestamp or a bare date. A bare date used as the end bound means "through
+// the end of that day", not midnight at its start, so endOfDay shifts it to
+// the last nanosecond of the day.
+func parseHistoryBound(isoDate string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, isoDate); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", isoDate); err == nil {
+		if endOfDay {
+			return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid ISO date %q", isoDate)
+}
+
+// GetDrugHistory returns every ledger-level modification to drugID whose
+// transaction timestamp falls within [fromISO, toISO], giving clients a
+// proper audit trail instead of reading the single latest state.
+func (s *SmartContract) GetDrugHistory(ctx contractapi.TransactionContextInterface, drugID string, fromISO string, toISO string) ([]HistoryEntry, error) {
+	from, err := parseHistoryBound(fromISO, false)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseHistoryBound(toISO, true)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := ctx.GetStub().GetHistoryForKey(drugID)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	entries := []HistoryEntry{}
+	for iterator.HasNext() {
+		modification, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		timestamp := modification.Timestamp.AsTime()
+		if timestamp.Before(from) || timestamp.After(to) {
+			continue
+		}
+
+		entry := HistoryEntry{
+			TxID:      modification.TxId,
+			Timestamp: timestamp.UTC().Format(time.RFC3339),
+			IsDelete:  modification.IsDelete,
+		}
+		if !modification.IsDelete && len(modification.Value) > 0 {
+			if err := json.Unmarshal(modification.Value, &entry.Drug); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ============== QUERY FUNCTIONS ==============
+
+// PaginatedDrugResult is the typed response returned by the rich-query
+// functions below, mirroring GetQueryResultWithPagination's bookmark contract.
+type PaginatedDrugResult struct {
+	Records        []Drug `json:"records"`
+	Bookmark       string `json:"bookmark"`
+	FetchedRecords int32  `json:"fetchedRecords"`
+}
+
+func runPaginatedQuery(ctx contractapi.TransactionContextInterface, selector string, pageSize int32, bookmark string) (*PaginatedDrugResult, error) {
+	resultsIterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(selector, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	drugs := []Drug{}
+	for resultsIterator.HasNext() {
+		queryResult, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		var drug Drug
+		if err := json.Unmarshal(queryResult.Value, &drug); err != nil {
+			return nil, err
+		}
+		drugs = append(drugs, drug)
+	}
+
+	return &PaginatedDrugResult{
+		Records:        drugs,
+		Bookmark:       metadata.Bookmark,
+		FetchedRecords: metadata.FetchedRecordsCount,
+	}, nil
+}
+
+func ownerSelector(owner string) map[string]interface{} {
+	return map[string]interface{}{
+		"selector": map[string]interface{}{"currentOwner": owner},
+	}
+}
+
+func statusSelector(status string) map[string]interface{} {
+	return map[string]interface{}{
+		"selector": map[string]interface{}{"status": status},
+	}
+}
+
+func recalledByManufacturerSelector(mfr string) map[string]interface{} {
+	return map[string]interface{}{
+		"selector": map[string]interface{}{"manufacturer": mfr, "isRecalled": true},
+	}
+}
+
+func expiringBeforeSelector(dateISO string) map[string]interface{} {
+	return map[string]interface{}{
+		"selector": map[string]interface{}{"expiryDate": map[string]interface{}{"$lt": dateISO}},
+	}
+}
+
+// QueryDrugsByOwner returns drugs currently held by the given owner, paginated.
+func (s *SmartContract) QueryDrugsByOwner(ctx contractapi.TransactionContextInterface, owner string, pageSize int32, bookmark string) (*PaginatedDrugResult, error) {
+	selector, err := json.Marshal(ownerSelector(owner))
+	if err != nil {
+		return nil, err
+	}
+	return runPaginatedQuery(ctx, string(selector), pageSize, bookmark)
+}
+
+// QueryDrugsByStatus returns drugs in the given status, paginated.
+func (s *SmartContract) QueryDrugsByStatus(ctx contractapi.TransactionContextInterface, status string, pageSize int32, bookmark string) (*PaginatedDrugResult, error) {
+	selector, err := json.Marshal(statusSelector(status))
+	if err != nil {
+		return nil, err
+	}
+	return runPaginatedQuery(ctx, string(selector), pageSize, bookmark)
+}
+
+// QueryRecalledDrugsByManufacturer returns recalled drugs from the given
+// manufacturer, paginated.
+func (s *SmartContract) QueryRecalledDrugsByManufacturer(ctx contractapi.TransactionContextInterface, mfr string, pageSize int32, bookmark string) (*PaginatedDrugResult, error) {
+	selector, err := json.Marshal(recalledByManufacturerSelector(mfr))
+	if err != nil {
+		return nil, err
+	}
+	return runPaginatedQuery(ctx, string(selector), pageSize, bookmark)
+}
+
+// QueryDrugsExpiringBefore returns drugs whose expiryDate is before the given
+// ISO-8601 date, paginated.
+func (s *SmartContract) QueryDrugsExpiringBefore(ctx contractapi.TransactionContextInterface, dateISO string, pageSize int32, bookmark string) (*PaginatedDrugResult, error) {
+	selector, err := json.Marshal(expiringBeforeSelector(dateISO))
+	if err != nil {
+		return nil, err
+	}
+	return runPaginatedQuery(ctx, string(selector), pageSize, bookmark)
+}
+
 // ============== MAIN ==============
 func main() {
 	chaincode, err := contractapi.NewChaincode(&SmartContract{})
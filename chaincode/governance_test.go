@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsThresholdMet(t *testing.T) {
+	cases := []struct {
+		name      string
+		endorsers []string
+		threshold int
+		want      bool
+	}{
+		{"below threshold", []string{"CDSCOMSP"}, 2, false},
+		{"exactly at threshold", []string{"CDSCOMSP", "StateFDAMSP"}, 2, true},
+		{"above threshold", []string{"CDSCOMSP", "StateFDAMSP", "WHOMSP"}, 2, true},
+		{"zero threshold with no endorsers", []string{}, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			action := PendingAction{Endorsers: tc.endorsers, Threshold: tc.threshold}
+			if got := isThresholdMet(action); got != tc.want {
+				t.Errorf("isThresholdMet(%v, threshold=%d) = %v, want %v", tc.endorsers, tc.threshold, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestActionExpiredAt(t *testing.T) {
+	expiry := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	action := PendingAction{ActionID: "a1", Expiry: expiry.Format(time.RFC3339)}
+
+	expired, err := actionExpiredAt(action, expiry.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired {
+		t.Errorf("action should not be expired a minute before its expiry")
+	}
+
+	expired, err = actionExpiredAt(action, expiry.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !expired {
+		t.Errorf("action should be expired a minute after its expiry")
+	}
+}
+
+func TestActionExpiredAtInvalidExpiry(t *testing.T) {
+	action := PendingAction{ActionID: "a1", Expiry: "not-a-timestamp"}
+	if _, err := actionExpiredAt(action, time.Now().UTC()); err == nil {
+		t.Errorf("expected an error for an unparseable Expiry, got nil")
+	}
+}
+
+func TestIsRegulator(t *testing.T) {
+	config := GovernanceConfig{Regulators: []string{"CDSCOMSP", "StateFDAMSP", "WHOMSP"}}
+
+	if !isRegulator(config, "CDSCOMSP") {
+		t.Errorf("expected CDSCOMSP to be recognized as a regulator")
+	}
+	if isRegulator(config, "CiplaMSP") {
+		t.Errorf("did not expect CiplaMSP to be recognized as a regulator")
+	}
+}
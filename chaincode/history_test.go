@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHistoryBoundFromBareDate(t *testing.T) {
+	got, err := parseHistoryBound("2026-07-01", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("from-bound = %v, want %v", got, want)
+	}
+}
+
+func TestParseHistoryBoundToBareDateIncludesWholeDay(t *testing.T) {
+	to, err := parseHistoryBound("2026-07-29", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lastMomentOfDay := time.Date(2026, 7, 29, 23, 59, 59, 0, time.UTC)
+	if to.Before(lastMomentOfDay) {
+		t.Errorf("to-bound %v excludes %v, the last day of the window", to, lastMomentOfDay)
+	}
+
+	nextDay := time.Date(2026, 7, 30, 0, 0, 0, 0, time.UTC)
+	if !to.Before(nextDay) {
+		t.Errorf("to-bound %v should not reach into the following day %v", to, nextDay)
+	}
+}
+
+func TestParseHistoryBoundRFC3339PassesThrough(t *testing.T) {
+	got, err := parseHistoryBound("2026-07-29T15:04:05Z", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 7, 29, 15, 4, 5, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got = %v, want %v (endOfDay must not apply to a full timestamp)", got, want)
+	}
+}
+
+func TestParseHistoryBoundInvalid(t *testing.T) {
+	if _, err := parseHistoryBound("not-a-date", false); err == nil {
+		t.Errorf("expected an error for an unparseable date")
+	}
+}
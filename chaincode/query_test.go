@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOwnerSelector(t *testing.T) {
+	bytes, err := json.Marshal(ownerSelector("CiplaMSP"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"selector":{"currentOwner":"CiplaMSP"}}`
+	if string(bytes) != want {
+		t.Errorf("got %s, want %s", bytes, want)
+	}
+}
+
+func TestStatusSelector(t *testing.T) {
+	bytes, err := json.Marshal(statusSelector("InTransit"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"selector":{"status":"InTransit"}}`
+	if string(bytes) != want {
+		t.Errorf("got %s, want %s", bytes, want)
+	}
+}
+
+func TestRecalledByManufacturerSelector(t *testing.T) {
+	bytes, err := json.Marshal(recalledByManufacturerSelector("Cipla"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"selector":{"isRecalled":true,"manufacturer":"Cipla"}}`
+	if string(bytes) != want {
+		t.Errorf("got %s, want %s", bytes, want)
+	}
+}
+
+func TestExpiringBeforeSelector(t *testing.T) {
+	bytes, err := json.Marshal(expiringBeforeSelector("2026-12-31"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"selector":{"expiryDate":{"$lt":"2026-12-31"}}}`
+	if string(bytes) != want {
+		t.Errorf("got %s, want %s", bytes, want)
+	}
+}
+
+func TestOwnerSelectorEscapesInput(t *testing.T) {
+	bytes, err := json.Marshal(ownerSelector(`Evil"; DROP`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded map[string]map[string]string
+	if err := json.Unmarshal(bytes, &decoded); err != nil {
+		t.Fatalf("marshaled selector is not valid JSON: %v", err)
+	}
+	if decoded["selector"]["currentOwner"] != `Evil"; DROP` {
+		t.Errorf("round-tripped owner = %q, want the raw input preserved", decoded["selector"]["currentOwner"])
+	}
+}